@@ -0,0 +1,161 @@
+package binserializer
+
+import (
+	"errors"
+	"io"
+	"math/bits"
+)
+
+// ErrInvalidBitWidth is returned by Bits when numBits is outside 1..32.
+var ErrInvalidBitWidth = errors.New("binser: numBits must be between 1 and 32")
+
+// ErrInvalidRange is returned by RangedInt when max is less than min.
+var ErrInvalidRange = errors.New("binser: max must be greater than or equal to min")
+
+// alignToByte pads a partially-filled write scratch out to buf, or discards
+// a partially-consumed read scratch, so the next byte-aligned method starts
+// on a fresh byte. It is a no-op if no bits are pending.
+func (b *Stream) alignToByte() {
+	if b.bitCount == 0 {
+		return
+	}
+	if b.reading {
+		b.bitBuf = 0
+		b.bitCount = 0
+		return
+	}
+	if !b.checkCap(1) {
+		return
+	}
+	b.buf = append(b.buf, byte(b.bitBuf))
+	b.pos++
+	b.bitBuf = 0
+	b.bitCount = 0
+}
+
+// Flush pads the stream to a byte boundary, writing out any partially-filled
+// byte still held in the bit scratch (or discarding any unread bits left in
+// the current byte, when reading). If a writer is given, the stream's
+// unconsumed buffered bytes are then drained to it, in the style of
+// WriteTo.
+func (b *Stream) Flush(w ...io.Writer) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	b.alignToByte()
+	if len(w) == 0 {
+		return 0, nil
+	}
+	n64, err := b.WriteTo(w[0])
+	return int(n64), err
+}
+
+// Bits reads or writes the low numBits bits of value, packing them into as
+// few bytes as possible across successive calls. numBits must be 1..32.
+func (b *Stream) Bits(value *uint32, numBits int) {
+	if b.err != nil {
+		return
+	}
+	if numBits < 1 || numBits > 32 {
+		b.err = ErrInvalidBitWidth
+		return
+	}
+	if b.reading {
+		var result uint32
+		var got uint
+		for got < uint(numBits) {
+			if b.bitCount == 0 {
+				if b.pos >= len(b.buf) {
+					b.err = io.EOF
+					return
+				}
+				b.bitBuf = uint64(b.buf[b.pos])
+				b.pos++
+				b.bitCount = 8
+			}
+			take := uint(numBits) - got
+			if take > b.bitCount {
+				take = b.bitCount
+			}
+			mask := uint64(1)<<take - 1
+			result |= uint32(b.bitBuf&mask) << got
+			b.bitBuf >>= take
+			b.bitCount -= take
+			got += take
+		}
+		*value = result
+		return
+	}
+
+	v := uint64(*value)
+	remaining := uint(numBits)
+	for remaining > 0 {
+		space := 8 - b.bitCount
+		take := remaining
+		if take > space {
+			take = space
+		}
+		mask := uint64(1)<<take - 1
+		b.bitBuf |= (v & mask) << b.bitCount
+		v >>= take
+		b.bitCount += take
+		remaining -= take
+		if b.bitCount == 8 {
+			if !b.checkCap(1) {
+				return
+			}
+			b.buf = append(b.buf, byte(b.bitBuf))
+			b.pos++
+			b.bitBuf = 0
+			b.bitCount = 0
+		}
+	}
+}
+
+// Bool reads or writes v as a single packed bit.
+func (b *Stream) Bool(v *bool) {
+	if b.err != nil {
+		return
+	}
+	var bit uint32
+	if !b.reading && *v {
+		bit = 1
+	}
+	b.Bits(&bit, 1)
+	if b.err != nil {
+		return
+	}
+	if b.reading {
+		*v = bit != 0
+	}
+}
+
+// RangedInt reads or writes v using the minimum number of bits needed to
+// represent any value in [min, max].
+func (b *Stream) RangedInt(v *int32, min, max int32) {
+	if b.err != nil {
+		return
+	}
+	if max < min {
+		b.err = ErrInvalidRange
+		return
+	}
+	numBits := bits.Len32(uint32(max - min))
+	if numBits == 0 {
+		if b.reading {
+			*v = min
+		}
+		return
+	}
+	if b.reading {
+		var raw uint32
+		b.Bits(&raw, numBits)
+		if b.err != nil {
+			return
+		}
+		*v = min + int32(raw)
+		return
+	}
+	raw := uint32(*v - min)
+	b.Bits(&raw, numBits)
+}
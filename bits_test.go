@@ -0,0 +1,143 @@
+package binserializer
+
+import (
+	"io"
+	"testing"
+)
+
+func TestStream_Bits(t *testing.T) {
+	w := NewWritingStream(10)
+	var a uint32 = 0x5
+	var b32 uint32 = 0x2a
+	w.Bits(&a, 3)
+	w.Bits(&b32, 7)
+	w.Flush()
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	r := w.Copy()
+	var a2, b2 uint32
+	r.Bits(&a2, 3)
+	r.Bits(&b2, 7)
+	if r.Error() != nil {
+		t.Fatal(r.Error())
+	}
+
+	if a2 != a || b2 != b32 {
+		t.Fatalf("expected %x,%x got %x,%x\n", a, b32, a2, b2)
+	}
+}
+
+func TestStream_BitsAcrossBytes(t *testing.T) {
+	w := NewWritingStream(10)
+	var v uint32 = 0x1ffff
+	w.Bits(&v, 17)
+	w.Flush()
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	r := w.Copy()
+	var v2 uint32
+	r.Bits(&v2, 17)
+	if r.Error() != nil {
+		t.Fatal(r.Error())
+	}
+
+	if v2 != v {
+		t.Fatalf("expected %x got %x\n", v, v2)
+	}
+}
+
+func TestStream_Bool(t *testing.T) {
+	w := NewWritingStream(10)
+	t1, f1 := true, false
+	w.Bool(&t1)
+	w.Bool(&f1)
+	w.Flush()
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	r := w.Copy()
+	var t2, f2 bool
+	r.Bool(&t2)
+	r.Bool(&f2)
+	if r.Error() != nil {
+		t.Fatal(r.Error())
+	}
+
+	if t2 != true || f2 != false {
+		t.Fatalf("expected true,false got %v,%v\n", t2, f2)
+	}
+}
+
+func TestStream_RangedInt(t *testing.T) {
+	w := NewWritingStream(10)
+	var v int32 = 42
+	w.RangedInt(&v, 0, 100)
+	w.Flush()
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	r := w.Copy()
+	var v2 int32
+	r.RangedInt(&v2, 0, 100)
+	if r.Error() != nil {
+		t.Fatal(r.Error())
+	}
+
+	if v2 != v {
+		t.Fatalf("expected %d got %d\n", v, v2)
+	}
+}
+
+func TestStream_Bits_RespectsFixedCap(t *testing.T) {
+	w := NewFixedWritingStream(1)
+	var a uint32 = 0x1
+	w.Bits(&a, 8)
+	var b uint32 = 0x1
+	w.Bits(&b, 8)
+	if w.Error() != io.ErrShortBuffer {
+		t.Fatalf("expected io.ErrShortBuffer got %v\n", w.Error())
+	}
+}
+
+func TestStream_AlignToByte_RespectsFixedCap(t *testing.T) {
+	w := NewFixedWritingStream(0)
+	var a uint32 = 0x1
+	w.Bits(&a, 3)
+	var n uint16 = 0xabcd
+	w.Uint16(&n)
+	if w.Error() != io.ErrShortBuffer {
+		t.Fatalf("expected io.ErrShortBuffer got %v\n", w.Error())
+	}
+}
+
+func TestStream_BitsThenByteAligned(t *testing.T) {
+	w := NewWritingStream(10)
+	var v uint32 = 0x3
+	w.Bits(&v, 3)
+	var n uint16 = 0xabcd
+	w.Uint16(&n)
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+	if w.Len() != 3 {
+		t.Fatalf("expected 3 bytes (1 padded + 2) got %d\n", w.Len())
+	}
+
+	r := w.Copy()
+	var v2 uint32
+	r.Bits(&v2, 3)
+	var n2 uint16
+	r.Uint16(&n2)
+	if r.Error() != nil {
+		t.Fatal(r.Error())
+	}
+	if v2 != v || n2 != n {
+		t.Fatalf("expected %x,%x got %x,%x\n", v, n, v2, n2)
+	}
+}
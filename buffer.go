@@ -1,6 +1,7 @@
 package binserializer
 
 import (
+	"encoding/binary"
 	"io"
 	"math"
 )
@@ -9,12 +10,59 @@ import (
 // does not need to externally manage where in the buffer they are currently reading
 // or writing to.
 type Stream struct {
-	buf     []byte // the backing byte slice
-	pos     int    // current position in read/write: TODO: use internal slice pos instead
-	reading bool   // is the buffer for reading or writing?
-	err     error  // records errors reading or writing
+	buf      []byte // the backing byte slice
+	pos      int    // current position in read/write: TODO: use internal slice pos instead
+	reading  bool   // is the buffer for reading or writing?
+	err      error  // records errors reading or writing
+	bitBuf   uint64 // scratch space for the bit-level methods
+	bitCount uint   // number of pending bits held in bitBuf
+	mode     Mode   // whether a write stream may grow past its initial capacity
+	order    binary.ByteOrder // byte order for multi-byte fields; nil means little-endian
+	maxFrameSize int // max ReadFrame payload length; 0 means DefaultMaxFrameSize
 }
 
+// ByteOrder returns the byte order this stream uses to encode multi-byte
+// fields. The zero value of a Stream uses binary.LittleEndian.
+func (b *Stream) ByteOrder() binary.ByteOrder {
+	if b.order == nil {
+		return binary.LittleEndian
+	}
+	return b.order
+}
+
+// SetByteOrder overrides the byte order used for multi-byte fields, e.g.
+// binary.BigEndian for interop with big-endian network protocols.
+func (b *Stream) SetByteOrder(order binary.ByteOrder) {
+	b.order = order
+}
+
+// MaxFrameSize returns the max payload length ReadFrame will accept. The
+// zero value of a Stream uses DefaultMaxFrameSize.
+func (b *Stream) MaxFrameSize() int {
+	if b.maxFrameSize == 0 {
+		return DefaultMaxFrameSize
+	}
+	return b.maxFrameSize
+}
+
+// SetMaxFrameSize overrides the max payload length ReadFrame will accept.
+func (b *Stream) SetMaxFrameSize(n int) {
+	b.maxFrameSize = n
+}
+
+// Mode controls whether a write Stream is allowed to grow its backing slice
+// past the capacity it was created with.
+type Mode int
+
+const (
+	// GrowAuto lets the backing slice grow as needed, the historical
+	// behavior of NewWritingStream.
+	GrowAuto Mode = iota
+	// FixedCap sets Stream.Error() to io.ErrShortBuffer instead of
+	// growing past the slice's original capacity.
+	FixedCap
+)
+
 // Creates a new writing Stream with a backing byte slice of the provided size
 func NewWritingStream(size int) Stream {
 	return Stream{
@@ -22,6 +70,30 @@ func NewWritingStream(size int) Stream {
 	}
 }
 
+// NewFixedWritingStream creates a writing Stream backed by a slice of the
+// provided size that refuses to grow: any write that would exceed size
+// sets Error() to io.ErrShortBuffer instead of reallocating.
+func NewFixedWritingStream(size int) Stream {
+	return Stream{
+		buf:  make([]byte, 0, size),
+		mode: FixedCap,
+	}
+}
+
+// checkCap returns true if a write of n more bytes is allowed. In FixedCap
+// mode it sets b.err to io.ErrShortBuffer and returns false once the write
+// would exceed the backing slice's capacity.
+func (b *Stream) checkCap(n int) bool {
+	if b.mode != FixedCap {
+		return true
+	}
+	if len(b.buf)+n > cap(b.buf) {
+		b.err = io.ErrShortBuffer
+		return false
+	}
+	return true
+}
+
 // Creates a new Stream using the original backing slice
 // If a buffer is provided with a length, then it will be a read-only stream
 // If a buffer has no length but a capacity, then it will be a write-only stream
@@ -63,7 +135,10 @@ func (b Stream) IsReading() bool {
 
 // Copy returns a copy of the Stream in read-only mode
 func (b Stream) Copy() Stream {
-	return NewReadingStreamCopy(b.buf)
+	s := NewReadingStreamCopy(b.buf)
+	s.order = b.order
+	s.maxFrameSize = b.maxFrameSize
+	return s
 }
 
 // Len returns the length of the backing byte slice
@@ -81,9 +156,14 @@ func (b *Stream) Pos() int {
 	return b.pos
 }
 
-// Resets the position back to beginning of buffer
+// Reset moves the position back to the beginning of the buffer. On a write
+// stream this also truncates the buffer to zero length so the next write
+// starts clean instead of overwriting previously written bytes in place.
 func (b *Stream) Reset() {
 	b.pos = 0
+	if !b.reading {
+		b.buf = b.buf[:0]
+	}
 }
 
 // GetBytes returns a byte slice possibly smaller than length if bytes are not
@@ -92,7 +172,8 @@ func (b *Stream) GetBytes(length int) (result []byte) {
 	if b.err != nil {
 		return
 	}
-	if len(b.buf) < length {
+	b.alignToByte()
+	if length < 0 || len(b.buf)-b.pos < length {
 		b.err = io.EOF
 		return nil
 	}
@@ -106,6 +187,7 @@ func (b *Stream) Uint8(n *uint8) {
 	if b.err != nil {
 		return
 	}
+	b.alignToByte()
 	if b.reading {
 		if b.pos+SizeUint8 > len(b.buf) {
 			b.err = io.EOF
@@ -116,6 +198,9 @@ func (b *Stream) Uint8(n *uint8) {
 		*n = uint8(buf[0])
 		return
 	}
+	if !b.checkCap(SizeUint8) {
+		return
+	}
 	b.buf = append(b.buf, *n)
 	b.pos++
 	return
@@ -126,17 +211,22 @@ func (b *Stream) Uint16(n *uint16) {
 	if b.err != nil {
 		return
 	}
+	b.alignToByte()
 	if b.reading {
 		buf := b.GetBytes(SizeUint16)
-		var v uint16
-		v |= uint16(buf[0])
-		v |= uint16(buf[1]) << 8
-		*n = v
+		if b.err != nil {
+			return
+		}
+		*n = b.ByteOrder().Uint16(buf)
 		return
 	}
-	b.buf = append(b.buf, byte(*n))
-	b.buf = append(b.buf, byte(*n >> 8))
-	b.pos += 2
+	if !b.checkCap(SizeUint16) {
+		return
+	}
+	var tmp [SizeUint16]byte
+	b.ByteOrder().PutUint16(tmp[:], *n)
+	b.buf = append(b.buf, tmp[:]...)
+	b.pos += SizeUint16
 	return
 }
 
@@ -145,21 +235,22 @@ func (b *Stream) Uint32(n *uint32) {
 	if b.err != nil {
 		return
 	}
+	b.alignToByte()
 	if b.reading {
 		buf := b.GetBytes(SizeUint32)
-		var v uint32
-		v |= uint32(buf[0])
-		v |= uint32(buf[1]) << 8
-		v |= uint32(buf[2]) << 16
-		v |= uint32(buf[3]) << 24
-		*n = v
+		if b.err != nil {
+			return
+		}
+		*n = b.ByteOrder().Uint32(buf)
 		return
 	}
-	b.buf = append(b.buf, byte(*n))
-	b.buf = append(b.buf, byte(*n >> 8))
-	b.buf = append(b.buf, byte(*n >> 16))
-	b.buf = append(b.buf, byte(*n >> 24))
-	b.pos += 4
+	if !b.checkCap(SizeUint32) {
+		return
+	}
+	var tmp [SizeUint32]byte
+	b.ByteOrder().PutUint32(tmp[:], *n)
+	b.buf = append(b.buf, tmp[:]...)
+	b.pos += SizeUint32
 	return
 }
 
@@ -168,29 +259,22 @@ func (b *Stream) Uint64(n *uint64) {
 	if b.err != nil {
 		return
 	}
+	b.alignToByte()
 	if b.reading {
 		buf := b.GetBytes(SizeUint64)
-		var v uint64
-		v |= uint64(buf[0])
-		v |= uint64(buf[1]) << 8
-		v |= uint64(buf[2]) << 16
-		v |= uint64(buf[3]) << 24
-		v |= uint64(buf[4]) << 32
-		v |= uint64(buf[5]) << 40
-		v |= uint64(buf[6]) << 48
-		v |= uint64(buf[7]) << 56
-		*n = v
+		if b.err != nil {
+			return
+		}
+		*n = b.ByteOrder().Uint64(buf)
 		return
 	}
-	b.buf = append(b.buf, byte(*n))
-	b.buf = append(b.buf, byte(*n >> 8))
-	b.buf = append(b.buf, byte(*n >> 16))
-	b.buf = append(b.buf, byte(*n >> 24))
-	b.buf = append(b.buf, byte(*n >> 32))
-	b.buf = append(b.buf, byte(*n >> 40))
-	b.buf = append(b.buf, byte(*n >> 48))
-	b.buf = append(b.buf, byte(*n >> 56))
-	b.pos += 4
+	if !b.checkCap(SizeUint64) {
+		return
+	}
+	var tmp [SizeUint64]byte
+	b.ByteOrder().PutUint64(tmp[:], *n)
+	b.buf = append(b.buf, tmp[:]...)
+	b.pos += SizeUint64
 	return
 }
 
@@ -199,6 +283,7 @@ func (b *Stream) Int8(n *int8) {
 	if b.err != nil {
 		return
 	}
+	b.alignToByte()
 	if b.reading {
 		if b.pos+1 > len(b.buf) {
 			b.err = io.EOF
@@ -209,6 +294,9 @@ func (b *Stream) Int8(n *int8) {
 		*n = int8(buf[0])
 		return
 	}
+	if !b.checkCap(SizeInt8) {
+		return
+	}
 	b.buf = append(b.buf, byte(*n))
 	b.pos++
 	return
@@ -219,20 +307,22 @@ func (b *Stream) Int16(n *int16) {
 	if b.err != nil {
 		return
 	}
+	b.alignToByte()
 	if b.reading {
 		buf := b.GetBytes(SizeInt16)
 		if b.err != nil {
 			return
 		}
-		var v int16
-		v |= int16(buf[0])
-		v |= int16(buf[1]) << 8
-		*n = v
+		*n = int16(b.ByteOrder().Uint16(buf))
 		return
 	}
-	b.buf = append(b.buf, byte(*n))
-	b.buf = append(b.buf, byte(*n >> 8))
-	b.pos += 2
+	if !b.checkCap(SizeInt16) {
+		return
+	}
+	var tmp [SizeInt16]byte
+	b.ByteOrder().PutUint16(tmp[:], uint16(*n))
+	b.buf = append(b.buf, tmp[:]...)
+	b.pos += SizeInt16
 	return
 }
 
@@ -241,24 +331,22 @@ func (b *Stream) Int32(n *int32) {
 	if b.err != nil {
 		return
 	}
+	b.alignToByte()
 	if b.reading {
 		buf := b.GetBytes(SizeInt32)
 		if b.err != nil {
 			return
 		}
-		var v int32
-		v |= int32(buf[0])
-		v |= int32(buf[1]) << 8
-		v |= int32(buf[2]) << 16
-		v |= int32(buf[3]) << 24
-		*n = v
+		*n = int32(b.ByteOrder().Uint32(buf))
 		return
 	}
-	b.buf = append(b.buf, byte(*n))
-	b.buf = append(b.buf, byte(*n >> 8))
-	b.buf = append(b.buf, byte(*n >> 16))
-	b.buf = append(b.buf, byte(*n >> 24))
-	b.pos += 4
+	if !b.checkCap(SizeInt32) {
+		return
+	}
+	var tmp [SizeInt32]byte
+	b.ByteOrder().PutUint32(tmp[:], uint32(*n))
+	b.buf = append(b.buf, tmp[:]...)
+	b.pos += SizeInt32
 	return
 }
 
@@ -267,32 +355,22 @@ func (b *Stream) Int64(n *int64) {
 	if b.err != nil {
 		return
 	}
+	b.alignToByte()
 	if b.reading {
 		buf := b.GetBytes(SizeInt64)
 		if b.err != nil {
 			return
 		}
-		var v int64
-		v |= int64(buf[0])
-		v |= int64(buf[1]) << 8
-		v |= int64(buf[2]) << 16
-		v |= int64(buf[3]) << 24
-		v |= int64(buf[4]) << 32
-		v |= int64(buf[5]) << 40
-		v |= int64(buf[6]) << 48
-		v |= int64(buf[7]) << 56
-		*n = v
+		*n = int64(b.ByteOrder().Uint64(buf))
 		return
 	}
-	b.buf = append(b.buf, byte(*n))
-	b.buf = append(b.buf, byte(*n >> 8))
-	b.buf = append(b.buf, byte(*n >> 16))
-	b.buf = append(b.buf, byte(*n >> 24))
-	b.buf = append(b.buf, byte(*n >> 32))
-	b.buf = append(b.buf, byte(*n >> 40))
-	b.buf = append(b.buf, byte(*n >> 48))
-	b.buf = append(b.buf, byte(*n >> 56))
-	b.pos += 4
+	if !b.checkCap(SizeInt64) {
+		return
+	}
+	var tmp [SizeInt64]byte
+	b.ByteOrder().PutUint64(tmp[:], uint64(*n))
+	b.buf = append(b.buf, tmp[:]...)
+	b.pos += SizeInt64
 	return
 }
 
@@ -338,10 +416,16 @@ func (b *Stream) GetByte() (result byte) {
 	return v
 }
 
-// WriteByte encodes a little-endian uint8 into the buffer.
-func (b *Stream) WriteByte(n byte) {
+// WriteByte encodes a little-endian uint8 into the buffer. It satisfies
+// io.ByteWriter.
+func (b *Stream) WriteByte(n byte) error {
+	b.alignToByte()
+	if !b.checkCap(SizeUint8) {
+		return b.err
+	}
 	b.buf = append(b.buf, uint8(n))
 	b.pos++
+	return b.err
 }
 
 // WriteBytes encodes a little-endian byte slice into the buffer
@@ -1,6 +1,8 @@
 package binserializer
 
 import (
+	"encoding/binary"
+	"io"
 	"testing"
 	"math"
 )
@@ -329,3 +331,110 @@ func TestStream_GetFloat64(t *testing.T) {
 		t.Fatal("expected ", math.MaxFloat64, " got ", v2)
 	}
 }
+
+func TestStream_FixedCapWritePastCapacity(t *testing.T) {
+	w := NewFixedWritingStream(2)
+	var v1 uint16 = 0xffff
+	w.Uint16(&v1)
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	var v2 uint8 = 1
+	w.Uint8(&v2)
+	if w.Error() != io.ErrShortBuffer {
+		t.Fatalf("expected io.ErrShortBuffer got %v\n", w.Error())
+	}
+}
+
+func TestStream_PosMixedWidths(t *testing.T) {
+	w := NewWritingStream(15)
+	var v8 uint8 = 1
+	var v16 uint16 = 2
+	var v32 uint32 = 3
+	var v64 uint64 = 4
+	w.Uint8(&v8)
+	w.Uint16(&v16)
+	w.Uint32(&v32)
+	w.Uint64(&v64)
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	if w.Pos() != 15 {
+		t.Fatalf("expected Pos() 15 got %d\n", w.Pos())
+	}
+	if w.Pos() != w.Len() {
+		t.Fatalf("expected Pos() to equal Len(), got %d and %d\n", w.Pos(), w.Len())
+	}
+}
+
+func TestStream_ResetTruncatesWriteStream(t *testing.T) {
+	w := NewWritingStream(10)
+	w.WriteBytes([]byte("abcde"))
+	if w.Len() != 5 {
+		t.Fatalf("expected length 5 got %d\n", w.Len())
+	}
+
+	w.Reset()
+	if w.Len() != 0 {
+		t.Fatalf("expected length 0 after Reset() got %d\n", w.Len())
+	}
+	if w.Pos() != 0 {
+		t.Fatalf("expected Pos() 0 after Reset() got %d\n", w.Pos())
+	}
+
+	w.WriteBytes([]byte("xy"))
+	if string(w.buf) != "xy" {
+		t.Fatalf("expected 'xy' got %q\n", w.buf)
+	}
+}
+
+func TestStream_DefaultByteOrderIsLittleEndian(t *testing.T) {
+	w := NewWritingStream(4)
+	var v uint32 = 0x01020304
+	w.Uint32(&v)
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	if !bytesEqual(w.buf, []byte{0x04, 0x03, 0x02, 0x01}) {
+		t.Fatalf("expected little-endian bytes got % x\n", w.buf)
+	}
+}
+
+func TestStream_BigEndian(t *testing.T) {
+	w := NewWritingStream(4)
+	w.SetByteOrder(binary.BigEndian)
+	var v uint32 = 0x01020304
+	w.Uint32(&v)
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	if !bytesEqual(w.buf, []byte{0x01, 0x02, 0x03, 0x04}) {
+		t.Fatalf("expected big-endian bytes got % x\n", w.buf)
+	}
+
+	r := w.Copy()
+	var v2 uint32
+	r.Uint32(&v2)
+	if r.Error() != nil {
+		t.Fatal(r.Error())
+	}
+	if v2 != v {
+		t.Fatalf("expected %x got %x\n", v, v2)
+	}
+}
+
+func bytesEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
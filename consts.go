@@ -0,0 +1,13 @@
+package binserializer
+
+// Size constants for the fixed-width codecs on Stream, in bytes.
+const (
+	SizeUint8  = 1
+	SizeUint16 = 2
+	SizeUint32 = 4
+	SizeUint64 = 8
+	SizeInt8   = 1
+	SizeInt16  = 2
+	SizeInt32  = 4
+	SizeInt64  = 8
+)
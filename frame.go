@@ -0,0 +1,175 @@
+package binserializer
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// DefaultMaxFrameSize bounds a frame's payload length when no other limit
+// has been configured, guarding decoders against a corrupt or malicious
+// length prefix.
+const DefaultMaxFrameSize = 1 << 20 // 1 MiB
+
+// ErrChecksumMismatch is returned when a frame's trailing CRC doesn't match
+// its payload.
+var ErrChecksumMismatch = errors.New("binser: frame checksum mismatch")
+
+// ErrFrameTooLarge is returned when a frame's length prefix exceeds the
+// configured maximum frame size.
+var ErrFrameTooLarge = errors.New("binser: frame exceeds max frame size")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// WriteFrame encodes payload as [varint length][payload][crc32c] onto the
+// stream.
+func (b *Stream) WriteFrame(payload []byte) {
+	if b.err != nil {
+		return
+	}
+	length := uint64(len(payload))
+	b.Uvarint(&length)
+	if b.err != nil {
+		return
+	}
+	b.WriteBytes(payload)
+	if b.err != nil {
+		return
+	}
+	sum := crc32.Checksum(payload, crcTable)
+	b.Uint32(&sum)
+}
+
+// ReadFrame decodes a frame written by WriteFrame, verifying its CRC and
+// rejecting payloads longer than MaxFrameSize (DefaultMaxFrameSize unless
+// overridden with SetMaxFrameSize).
+func (b *Stream) ReadFrame() ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	var length uint64
+	b.Uvarint(&length)
+	if b.err != nil {
+		return nil, b.err
+	}
+	if length > uint64(b.MaxFrameSize()) {
+		b.err = ErrFrameTooLarge
+		return nil, b.err
+	}
+	payload := b.GetBytes(int(length))
+	if b.err != nil {
+		return nil, b.err
+	}
+	var sum uint32
+	b.Uint32(&sum)
+	if b.err != nil {
+		return nil, b.err
+	}
+	if crc32.Checksum(payload, crcTable) != sum {
+		b.err = ErrChecksumMismatch
+		return nil, b.err
+	}
+	return payload, nil
+}
+
+// FrameWriter writes a stream of length-prefixed, checksummed frames to an
+// underlying io.Writer, for callers that want to emit frames one at a time
+// instead of buffering a whole Stream in memory.
+type FrameWriter struct {
+	w            io.Writer
+	MaxFrameSize int
+}
+
+// NewFrameWriter wraps w with the DefaultMaxFrameSize limit.
+func NewFrameWriter(w io.Writer) *FrameWriter {
+	return &FrameWriter{w: w, MaxFrameSize: DefaultMaxFrameSize}
+}
+
+// WriteFrame encodes and writes a single frame.
+func (fw *FrameWriter) WriteFrame(payload []byte) error {
+	if fw.MaxFrameSize > 0 && len(payload) > fw.MaxFrameSize {
+		return ErrFrameTooLarge
+	}
+	if err := writeUvarintTo(fw.w, uint64(len(payload))); err != nil {
+		return err
+	}
+	if _, err := fw.w.Write(payload); err != nil {
+		return err
+	}
+	var sumBuf [4]byte
+	binary.LittleEndian.PutUint32(sumBuf[:], crc32.Checksum(payload, crcTable))
+	_, err := fw.w.Write(sumBuf[:])
+	return err
+}
+
+// FrameReader reads a stream of frames written by FrameWriter (or
+// Stream.WriteFrame on a little-endian Stream) from an underlying io.Reader.
+// It always decodes the CRC footer as little-endian.
+type FrameReader struct {
+	r            io.Reader
+	MaxFrameSize int
+}
+
+// NewFrameReader wraps r with the DefaultMaxFrameSize limit.
+func NewFrameReader(r io.Reader) *FrameReader {
+	return &FrameReader{r: r, MaxFrameSize: DefaultMaxFrameSize}
+}
+
+// ReadFrame reads, length-checks and checksum-verifies a single frame.
+func (fr *FrameReader) ReadFrame() ([]byte, error) {
+	length, err := readUvarintFrom(fr.r)
+	if err != nil {
+		return nil, err
+	}
+	if fr.MaxFrameSize > 0 && length > uint64(fr.MaxFrameSize) {
+		return nil, ErrFrameTooLarge
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(fr.r, payload); err != nil {
+		return nil, err
+	}
+	var sumBuf [4]byte
+	if _, err := io.ReadFull(fr.r, sumBuf[:]); err != nil {
+		return nil, err
+	}
+	if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(sumBuf[:]) {
+		return nil, ErrChecksumMismatch
+	}
+	return payload, nil
+}
+
+func writeUvarintTo(w io.Writer, v uint64) error {
+	var buf [binary.MaxVarintLen64]byte
+	i := 0
+	for v >= 0x80 {
+		buf[i] = byte(v) | 0x80
+		v >>= 7
+		i++
+	}
+	buf[i] = byte(v)
+	_, err := w.Write(buf[:i+1])
+	return err
+}
+
+func readUvarintFrom(r io.Reader) (uint64, error) {
+	var x uint64
+	var s uint
+	var buf [1]byte
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, err
+		}
+		by := buf[0]
+		if by < 0x80 {
+			if i == binary.MaxVarintLen64-1 && by > 1 {
+				return 0, ErrOverflow
+			}
+			x |= uint64(by) << s
+			return x, nil
+		}
+		x |= uint64(by&0x7f) << s
+		s += 7
+	}
+	return 0, ErrOverflow
+}
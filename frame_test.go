@@ -0,0 +1,131 @@
+package binserializer
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStream_WriteReadFrame(t *testing.T) {
+	w := NewWritingStream(16)
+	w.WriteFrame([]byte("hello frame"))
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	r := w.Copy()
+	payload, err := r.ReadFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(payload) != "hello frame" {
+		t.Fatalf("expected 'hello frame' got %q\n", payload)
+	}
+}
+
+func TestStream_ReadFrame_ChecksumMismatch(t *testing.T) {
+	w := NewWritingStream(16)
+	w.WriteFrame([]byte("hello"))
+
+	buf, _ := w.Bytes()
+	buf[len(buf)-1] ^= 0xff
+
+	r := NewStream(buf)
+	if _, err := r.ReadFrame(); err != ErrChecksumMismatch {
+		t.Fatalf("expected ErrChecksumMismatch got %v\n", err)
+	}
+}
+
+func TestStream_ReadFrame_TruncatedPastPosDoesNotPanic(t *testing.T) {
+	w := NewWritingStream(20)
+	var prefix uint64 = 42
+	w.Uint64(&prefix)
+
+	var length uint64 = 12
+	w.Uvarint(&length)
+	w.WriteBytes([]byte("abcdef"))
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	r := NewStream(mustBytes(t, &w))
+	var v uint64
+	r.Uint64(&v)
+	if r.Error() != nil {
+		t.Fatal(r.Error())
+	}
+
+	if _, err := r.ReadFrame(); err == nil {
+		t.Fatal("expected an error for a frame truncated past the read cursor, got nil")
+	}
+}
+
+func TestStream_ReadFrame_TooLarge(t *testing.T) {
+	w := NewWritingStream(16)
+	length := uint64(DefaultMaxFrameSize + 1)
+	w.Uvarint(&length)
+
+	r := NewStream(mustBytes(t, &w))
+	if _, err := r.ReadFrame(); err != ErrFrameTooLarge {
+		t.Fatalf("expected ErrFrameTooLarge got %v\n", err)
+	}
+}
+
+func TestStream_ReadFrame_ConfigurableMaxFrameSize(t *testing.T) {
+	w := NewWritingStream(16)
+	w.WriteFrame([]byte("too big"))
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+
+	r := NewStream(mustBytes(t, &w))
+	r.SetMaxFrameSize(4)
+	if _, err := r.ReadFrame(); err != ErrFrameTooLarge {
+		t.Fatalf("expected ErrFrameTooLarge got %v\n", err)
+	}
+}
+
+func mustBytes(t *testing.T, s *Stream) []byte {
+	t.Helper()
+	buf, err := s.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
+
+func TestFrameWriterReader(t *testing.T) {
+	var buf bytes.Buffer
+	fw := NewFrameWriter(&buf)
+	if err := fw.WriteFrame([]byte("first")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.WriteFrame([]byte("second")); err != nil {
+		t.Fatal(err)
+	}
+
+	fr := NewFrameReader(&buf)
+	p1, err := fr.ReadFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(p1) != "first" {
+		t.Fatalf("expected 'first' got %q\n", p1)
+	}
+
+	p2, err := fr.ReadFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(p2) != "second" {
+		t.Fatalf("expected 'second' got %q\n", p2)
+	}
+}
+
+func TestFrameWriter_MaxFrameSize(t *testing.T) {
+	var buf bytes.Buffer
+	fw := NewFrameWriter(&buf)
+	fw.MaxFrameSize = 2
+	if err := fw.WriteFrame([]byte("too big")); err != ErrFrameTooLarge {
+		t.Fatalf("expected ErrFrameTooLarge got %v\n", err)
+	}
+}
@@ -0,0 +1,96 @@
+package binserializer
+
+import "io"
+
+// NewReaderFromReader reads up to size bytes from r and returns a reading
+// Stream over them. Fewer bytes than size are kept if r runs out early.
+func NewReaderFromReader(r io.Reader, size int) Stream {
+	buf := make([]byte, size)
+	n, err := io.ReadFull(r, buf)
+	s := Stream{buf: buf[:n], reading: true}
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		s.err = err
+	}
+	return s
+}
+
+// Read copies buffered, unread bytes into p. It satisfies io.Reader.
+func (b *Stream) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	b.alignToByte()
+	if b.pos >= len(b.buf) {
+		return 0, io.EOF
+	}
+	n := copy(p, b.buf[b.pos:])
+	b.pos += n
+	return n, nil
+}
+
+// Write appends p to the stream's buffer. It satisfies io.Writer.
+func (b *Stream) Write(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	b.alignToByte()
+	if !b.checkCap(len(p)) {
+		return 0, b.err
+	}
+	b.buf = append(b.buf, p...)
+	b.pos += len(p)
+	return len(p), nil
+}
+
+// ReadByte reads a single byte. It satisfies io.ByteReader.
+func (b *Stream) ReadByte() (byte, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	v := b.GetByte()
+	return v, b.err
+}
+
+// ReadFrom drains r into the stream's buffer until r returns io.EOF. It
+// satisfies io.ReaderFrom.
+func (b *Stream) ReadFrom(r io.Reader) (int64, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	b.alignToByte()
+	var total int64
+	chunk := make([]byte, 4096)
+	for {
+		n, err := r.Read(chunk)
+		if n > 0 {
+			if !b.checkCap(n) {
+				return total, b.err
+			}
+			b.buf = append(b.buf, chunk[:n]...)
+			b.pos += n
+			total += int64(n)
+		}
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			b.err = err
+			return total, err
+		}
+	}
+}
+
+// WriteTo writes the stream's unconsumed buffered bytes to w. It satisfies
+// io.WriterTo.
+func (b *Stream) WriteTo(w io.Writer) (int64, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	b.alignToByte()
+	n, err := w.Write(b.buf[b.pos:])
+	b.pos += n
+	if err != nil {
+		b.err = err
+	}
+	return int64(n), err
+}
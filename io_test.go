@@ -0,0 +1,129 @@
+package binserializer
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestStream_WriteRead(t *testing.T) {
+	w := NewWritingStream(10)
+	n, err := w.Write([]byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 {
+		t.Fatalf("expected 5 got %d\n", n)
+	}
+
+	r := w.Copy()
+	p := make([]byte, 5)
+	n, err = r.Read(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(p[:n]) != "hello" {
+		t.Fatalf("expected hello got %s\n", p[:n])
+	}
+
+	n, err = r.Read(p)
+	if err == nil || n != 0 {
+		t.Fatalf("expected EOF got n=%d err=%v\n", n, err)
+	}
+}
+
+func TestStream_ReadWriteByte(t *testing.T) {
+	w := NewWritingStream(1)
+	if err := w.WriteByte('z'); err != nil {
+		t.Fatal(err)
+	}
+
+	r := w.Copy()
+	v, err := r.ReadByte()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 'z' {
+		t.Fatalf("expected z got %c\n", v)
+	}
+}
+
+func TestStream_Write_RespectsFixedCap(t *testing.T) {
+	w := NewFixedWritingStream(4)
+	if _, err := w.Write([]byte("hello")); err != io.ErrShortBuffer {
+		t.Fatalf("expected io.ErrShortBuffer got %v\n", err)
+	}
+}
+
+func TestStream_ReadFrom(t *testing.T) {
+	var w Stream
+	src := bytes.NewBufferString("abcdef")
+	n, err := w.ReadFrom(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 6 {
+		t.Fatalf("expected 6 got %d\n", n)
+	}
+
+	r := w.Copy()
+	data := r.GetBytes(6)
+	if r.Error() != nil {
+		t.Fatal(r.Error())
+	}
+	if string(data) != "abcdef" {
+		t.Fatalf("expected abcdef got %s\n", data)
+	}
+}
+
+func TestStream_WriteTo(t *testing.T) {
+	w := NewWritingStream(10)
+	w.WriteBytes([]byte("xyz"))
+
+	r := w.Copy()
+	var dst bytes.Buffer
+	n, err := r.WriteTo(&dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 got %d\n", n)
+	}
+	if dst.String() != "xyz" {
+		t.Fatalf("expected xyz got %s\n", dst.String())
+	}
+}
+
+func TestNewReaderFromReader(t *testing.T) {
+	src := bytes.NewBufferString("0123456789")
+	s := NewReaderFromReader(src, 5)
+	if s.Error() != nil {
+		t.Fatal(s.Error())
+	}
+
+	data := s.GetBytes(5)
+	if s.Error() != nil {
+		t.Fatal(s.Error())
+	}
+	if string(data) != "01234" {
+		t.Fatalf("expected 01234 got %s\n", data)
+	}
+}
+
+func TestStream_FlushToWriter(t *testing.T) {
+	w := NewWritingStream(10)
+	w.WriteBytes([]byte("abc"))
+
+	r := w.Copy()
+	var dst bytes.Buffer
+	n, err := r.Flush(&dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 got %d\n", n)
+	}
+	if dst.String() != "abc" {
+		t.Fatalf("expected abc got %s\n", dst.String())
+	}
+}
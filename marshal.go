@@ -0,0 +1,243 @@
+package binserializer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrMaxLenExceeded is returned when a string or slice field's encoded or
+// decoded length is greater than the `maxlen` tag allows, or when a decoded
+// slice's length is greater than the number of bytes left in the input.
+var ErrMaxLenExceeded = errors.New("binser: length exceeds maxlen")
+
+// Marshal encodes v, which must be a struct or a pointer to one, into a new
+// byte slice using the same little-endian layout as the Stream methods.
+func Marshal(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, errors.New("binser: Marshal called with nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("binser: Marshal requires a struct, got %s", rv.Kind())
+	}
+	s := NewWritingStream(64)
+	structFields(&s, rv)
+	return s.Bytes()
+}
+
+// Unmarshal decodes data into v, which must be a non-nil pointer to a struct,
+// using the same field order and layout that Marshal produces.
+func Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("binser: Unmarshal requires a non-nil pointer")
+	}
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("binser: Unmarshal requires a pointer to struct, got %s", rv.Kind())
+	}
+	// NewStream infers write mode for nil/zero-length data, which is wrong
+	// here: Unmarshal always decodes, even from an empty payload.
+	s := Stream{buf: data, reading: true}
+	structFields(&s, rv)
+	return s.Error()
+}
+
+// tagOpts holds the parsed contents of a `binser:"..."` struct tag.
+type tagOpts struct {
+	skip   bool
+	maxlen int
+}
+
+func parseTag(raw string) tagOpts {
+	var opts tagOpts
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "skip":
+			opts.skip = true
+		case strings.HasPrefix(part, "maxlen="):
+			if n, err := strconv.Atoi(strings.TrimPrefix(part, "maxlen=")); err == nil {
+				opts.maxlen = n
+			}
+		}
+	}
+	return opts
+}
+
+// structFields reads or writes every exported, non-skipped field of rv in
+// declaration order.
+func structFields(b *Stream, rv reflect.Value) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		opts := parseTag(field.Tag.Get("binser"))
+		if opts.skip {
+			continue
+		}
+		reflectValue(b, rv.Field(i), opts)
+		if b.err != nil {
+			return
+		}
+	}
+}
+
+// reflectValue reads or writes a single field, recursing into nested
+// structs, arrays and slices.
+func reflectValue(b *Stream, fv reflect.Value, opts tagOpts) {
+	switch fv.Kind() {
+	case reflect.Uint8:
+		v := uint8(fv.Uint())
+		b.Uint8(&v)
+		if b.reading {
+			fv.SetUint(uint64(v))
+		}
+	case reflect.Uint16:
+		v := uint16(fv.Uint())
+		b.Uint16(&v)
+		if b.reading {
+			fv.SetUint(uint64(v))
+		}
+	case reflect.Uint32:
+		v := uint32(fv.Uint())
+		b.Uint32(&v)
+		if b.reading {
+			fv.SetUint(uint64(v))
+		}
+	case reflect.Uint64:
+		v := fv.Uint()
+		b.Uint64(&v)
+		if b.reading {
+			fv.SetUint(v)
+		}
+	case reflect.Int8:
+		v := int8(fv.Int())
+		b.Int8(&v)
+		if b.reading {
+			fv.SetInt(int64(v))
+		}
+	case reflect.Int16:
+		v := int16(fv.Int())
+		b.Int16(&v)
+		if b.reading {
+			fv.SetInt(int64(v))
+		}
+	case reflect.Int32:
+		v := int32(fv.Int())
+		b.Int32(&v)
+		if b.reading {
+			fv.SetInt(int64(v))
+		}
+	case reflect.Int64:
+		v := fv.Int()
+		b.Int64(&v)
+		if b.reading {
+			fv.SetInt(v)
+		}
+	case reflect.Float32:
+		v := float32(fv.Float())
+		b.Float32(&v)
+		if b.reading {
+			fv.SetFloat(float64(v))
+		}
+	case reflect.Float64:
+		v := fv.Float()
+		b.Float64(&v)
+		if b.reading {
+			fv.SetFloat(v)
+		}
+	case reflect.String:
+		reflectString(b, fv, opts)
+	case reflect.Slice:
+		reflectSlice(b, fv, opts)
+	case reflect.Array:
+		for i := 0; i < fv.Len(); i++ {
+			reflectValue(b, fv.Index(i), tagOpts{})
+			if b.err != nil {
+				return
+			}
+		}
+	case reflect.Struct:
+		structFields(b, fv)
+	default:
+		b.err = fmt.Errorf("binser: unsupported field kind %s", fv.Kind())
+	}
+}
+
+func reflectString(b *Stream, fv reflect.Value, opts tagOpts) {
+	if b.reading {
+		var length uint64
+		b.Uvarint(&length)
+		if b.err != nil {
+			return
+		}
+		if opts.maxlen > 0 && int(length) > opts.maxlen {
+			b.err = ErrMaxLenExceeded
+			return
+		}
+		data := b.GetBytes(int(length))
+		if b.err != nil {
+			return
+		}
+		fv.SetString(string(data))
+		return
+	}
+	s := fv.String()
+	if opts.maxlen > 0 && len(s) > opts.maxlen {
+		b.err = ErrMaxLenExceeded
+		return
+	}
+	length := uint64(len(s))
+	b.Uvarint(&length)
+	b.WriteBytes([]byte(s))
+}
+
+func reflectSlice(b *Stream, fv reflect.Value, opts tagOpts) {
+	if b.reading {
+		var length uint64
+		b.Uvarint(&length)
+		if b.err != nil {
+			return
+		}
+		// Even with no maxlen tag, a slice can't have more elements than
+		// there are bytes left to decode them from.
+		if length > uint64(len(b.buf)-b.pos) {
+			b.err = ErrMaxLenExceeded
+			return
+		}
+		if opts.maxlen > 0 && int(length) > opts.maxlen {
+			b.err = ErrMaxLenExceeded
+			return
+		}
+		slice := reflect.MakeSlice(fv.Type(), int(length), int(length))
+		for i := 0; i < int(length); i++ {
+			reflectValue(b, slice.Index(i), tagOpts{})
+			if b.err != nil {
+				return
+			}
+		}
+		fv.Set(slice)
+		return
+	}
+	if opts.maxlen > 0 && fv.Len() > opts.maxlen {
+		b.err = ErrMaxLenExceeded
+		return
+	}
+	length := uint64(fv.Len())
+	b.Uvarint(&length)
+	for i := 0; i < fv.Len(); i++ {
+		reflectValue(b, fv.Index(i), tagOpts{})
+		if b.err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package binserializer
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+type marshalInner struct {
+	A int32
+	B float64
+}
+
+type marshalOuter struct {
+	Name    string `binser:"maxlen=16"`
+	Values  []uint16
+	Inner   marshalInner
+	Tag     [4]byte
+	Ignored string `binser:"skip"`
+	private int
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	in := marshalOuter{
+		Name:   "hello",
+		Values: []uint16{1, 2, 3},
+		Inner:  marshalInner{A: -42, B: 3.14},
+		Tag:    [4]byte{1, 2, 3, 4},
+	}
+
+	data, err := Marshal(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out marshalOuter
+	out.Ignored = "untouched"
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	in.Ignored = ""
+	out.Ignored = ""
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("expected %+v got %+v\n", in, out)
+	}
+}
+
+func TestMarshal_MaxLenExceeded(t *testing.T) {
+	in := marshalOuter{Name: "this name is far too long"}
+
+	if _, err := Marshal(&in); err != ErrMaxLenExceeded {
+		t.Fatalf("expected ErrMaxLenExceeded got %v\n", err)
+	}
+}
+
+func TestUnmarshal_RequiresPointer(t *testing.T) {
+	var out marshalOuter
+	if err := Unmarshal(nil, out); err == nil {
+		t.Fatal("expected error for non-pointer target")
+	}
+}
+
+func TestUnmarshal_NilDataDecodesInsteadOfWriting(t *testing.T) {
+	out := marshalOuter{Name: "untouched", Values: []uint16{9}}
+	if err := Unmarshal(nil, &out); err == nil {
+		t.Fatal("expected an error decoding from nil data, got nil")
+	}
+}
+
+type sliceOnly struct {
+	Values []uint16
+}
+
+func TestUnmarshal_SliceLengthBoundedByRemainingBytes(t *testing.T) {
+	w := NewWritingStream(10)
+	var length uint64 = math.MaxUint64
+	w.Uvarint(&length)
+	if w.Error() != nil {
+		t.Fatal(w.Error())
+	}
+	data := mustBytes(t, &w)
+
+	var out sliceOnly
+	if err := Unmarshal(data, &out); err != ErrMaxLenExceeded {
+		t.Fatalf("expected ErrMaxLenExceeded got %v\n", err)
+	}
+}
@@ -0,0 +1,124 @@
+package binserializer
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrOverflow is returned by the varint methods when a value would need
+// more than 64 bits to represent, or when the buffer runs out before a
+// terminating byte is read.
+var ErrOverflow = errors.New("binser: varint overflows 64 bits")
+
+// Uvarint reads or writes n using Go's standard variable-length encoding:
+// 7 data bits per byte, with the high bit set on every byte but the last.
+func (b *Stream) Uvarint(n *uint64) {
+	if b.err != nil {
+		return
+	}
+	b.alignToByte()
+	if b.reading {
+		var x uint64
+		var s uint
+		for i := 0; i < binary.MaxVarintLen64; i++ {
+			if b.pos >= len(b.buf) {
+				b.err = ErrOverflow
+				return
+			}
+			by := b.buf[b.pos]
+			b.pos++
+			if by < 0x80 {
+				if i == binary.MaxVarintLen64-1 && by > 1 {
+					b.err = ErrOverflow
+					return
+				}
+				x |= uint64(by) << s
+				*n = x
+				return
+			}
+			x |= uint64(by&0x7f) << s
+			s += 7
+		}
+		b.err = ErrOverflow
+		return
+	}
+	x := *n
+	for x >= 0x80 {
+		if !b.checkCap(1) {
+			return
+		}
+		b.buf = append(b.buf, byte(x)|0x80)
+		b.pos++
+		x >>= 7
+	}
+	if !b.checkCap(1) {
+		return
+	}
+	b.buf = append(b.buf, byte(x))
+	b.pos++
+}
+
+// Varint reads or writes n using zigzag-encoded Uvarint, so small negative
+// values take as few bytes as small positive ones.
+func (b *Stream) Varint(n *int64) {
+	if b.err != nil {
+		return
+	}
+	if b.reading {
+		var u uint64
+		b.Uvarint(&u)
+		if b.err != nil {
+			return
+		}
+		*n = int64(u>>1) ^ -int64(u&1)
+		return
+	}
+	u := uint64(*n<<1) ^ uint64(*n>>63)
+	b.Uvarint(&u)
+}
+
+// Uvarint32 reads or writes a uint32 using the same encoding as Uvarint,
+// setting ErrOverflow if a decoded value doesn't fit in 32 bits.
+func (b *Stream) Uvarint32(n *uint32) {
+	if b.err != nil {
+		return
+	}
+	if b.reading {
+		var v uint64
+		b.Uvarint(&v)
+		if b.err != nil {
+			return
+		}
+		if v > 0xffffffff {
+			b.err = ErrOverflow
+			return
+		}
+		*n = uint32(v)
+		return
+	}
+	v := uint64(*n)
+	b.Uvarint(&v)
+}
+
+// Varint32 reads or writes an int32 using the same zigzag encoding as
+// Varint, setting ErrOverflow if a decoded value doesn't fit in 32 bits.
+func (b *Stream) Varint32(n *int32) {
+	if b.err != nil {
+		return
+	}
+	if b.reading {
+		var v int64
+		b.Varint(&v)
+		if b.err != nil {
+			return
+		}
+		if v > 0x7fffffff || v < -0x80000000 {
+			b.err = ErrOverflow
+			return
+		}
+		*n = int32(v)
+		return
+	}
+	v := int64(*n)
+	b.Varint(&v)
+}
@@ -0,0 +1,117 @@
+package binserializer
+
+import (
+	"io"
+	"math"
+	"testing"
+)
+
+func TestStream_Uvarint(t *testing.T) {
+	for _, v1 := range []uint64{0, 1, 127, 128, 300, math.MaxUint32, math.MaxUint64} {
+		writer := NewWritingStream(10)
+		writer.Uvarint(&v1)
+		if writer.Error() != nil {
+			t.Fatal(writer.Error())
+		}
+
+		reader := writer.Copy()
+		var v2 uint64
+		reader.Uvarint(&v2)
+		if reader.Error() != nil {
+			t.Fatal(reader.Error())
+		}
+
+		if v2 != v1 {
+			t.Fatalf("expected %d got %d\n", v1, v2)
+		}
+	}
+}
+
+func TestStream_Varint(t *testing.T) {
+	for _, v1 := range []int64{0, 1, -1, 63, -64, math.MaxInt64, math.MinInt64} {
+		writer := NewWritingStream(10)
+		writer.Varint(&v1)
+		if writer.Error() != nil {
+			t.Fatal(writer.Error())
+		}
+
+		reader := writer.Copy()
+		var v2 int64
+		reader.Varint(&v2)
+		if reader.Error() != nil {
+			t.Fatal(reader.Error())
+		}
+
+		if v2 != v1 {
+			t.Fatalf("expected %d got %d\n", v1, v2)
+		}
+	}
+}
+
+func TestStream_Uvarint32(t *testing.T) {
+	writer := NewWritingStream(10)
+	var v1 uint32 = math.MaxUint32
+	writer.Uvarint32(&v1)
+	reader := writer.Copy()
+
+	var v2 uint32
+	reader.Uvarint32(&v2)
+	if reader.Error() != nil {
+		t.Fatal(reader.Error())
+	}
+
+	if v2 != v1 {
+		t.Fatalf("expected %d got %d\n", v1, v2)
+	}
+}
+
+func TestStream_Varint32(t *testing.T) {
+	writer := NewWritingStream(10)
+	var v1 int32 = math.MinInt32
+	writer.Varint32(&v1)
+	reader := writer.Copy()
+
+	var v2 int32
+	reader.Varint32(&v2)
+	if reader.Error() != nil {
+		t.Fatal(reader.Error())
+	}
+
+	if v2 != v1 {
+		t.Fatalf("expected %d got %d\n", v1, v2)
+	}
+}
+
+func TestStream_UvarintOverflow(t *testing.T) {
+	buf := make([]byte, 10)
+	for i := range buf {
+		buf[i] = 0x80
+	}
+	b := NewStream(buf)
+
+	var v uint64
+	b.Uvarint(&v)
+	if b.Error() != ErrOverflow {
+		t.Fatalf("expected ErrOverflow got %v\n", b.Error())
+	}
+}
+
+func TestStream_Uvarint_RespectsFixedCap(t *testing.T) {
+	w := NewFixedWritingStream(1)
+	v := uint64(300) // needs 2 bytes
+	w.Uvarint(&v)
+	if w.Error() != io.ErrShortBuffer {
+		t.Fatalf("expected io.ErrShortBuffer got %v\n", w.Error())
+	}
+}
+
+func TestStream_UvarintTruncated(t *testing.T) {
+	buf := []byte{0x80, 0x80}
+	b := NewStream(buf)
+
+	var v uint64
+	b.Uvarint(&v)
+	if b.Error() != ErrOverflow {
+		t.Fatalf("expected ErrOverflow got %v\n", b.Error())
+	}
+}